@@ -0,0 +1,50 @@
+package errbin
+
+import "github.com/gin-gonic/gin"
+
+// Chain is an immutable, reusable bundle of ErrorMiddleware. Unlike
+// MiddlewareChain, which flattens a variadic list into a single
+// ErrorMiddleware at the call site, a Chain can be built up once (e.g.
+// authChain := errbin.NewChain(requireAuth, requireRole)) and shared across
+// multiple registrations without re-declaring the list each time.
+//
+// The zero value Chain{} is a valid, empty chain.
+type Chain struct {
+	mws []ErrorMiddleware
+}
+
+// NewChain creates a Chain from the given middlewares, applied in order
+// (mws[0] runs outermost).
+func NewChain(mws ...ErrorMiddleware) Chain {
+	return Chain{mws: append([]ErrorMiddleware(nil), mws...)}
+}
+
+// Append returns a new Chain with mws appended after c's existing
+// middlewares. c itself is left unmodified.
+func (c Chain) Append(mws ...ErrorMiddleware) Chain {
+	merged := make([]ErrorMiddleware, 0, len(c.mws)+len(mws))
+	merged = append(merged, c.mws...)
+	merged = append(merged, mws...)
+	return Chain{mws: merged}
+}
+
+// Extend returns a new Chain with other's middlewares appended after c's.
+// c and other are left unmodified.
+func (c Chain) Extend(other Chain) Chain {
+	return c.Append(other.mws...)
+}
+
+// Then wraps h with c's middlewares, innermost-out, equivalent to
+// mws[0](mws[1](...mws[n-1](h))).
+func (c Chain) Then(h ErrorHandler) ErrorHandler {
+	for i := len(c.mws) - 1; i >= 0; i-- {
+		h = c.mws[i](h)
+	}
+	return h
+}
+
+// ThenFunc is a convenience wrapper for Then that accepts a plain function
+// instead of an ErrorHandler.
+func (c Chain) ThenFunc(fn func(error, *gin.Context)) ErrorHandler {
+	return c.Then(ErrorHandler(fn))
+}
@@ -2,8 +2,6 @@
 package errbin
 
 import (
-	"errors"
-	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -17,72 +15,41 @@ type ErrorHandler func(error, *gin.Context)
 // ErrorMiddleware is a function type that execute between the last/next ErrorHandler.
 type ErrorMiddleware func(ErrorHandler) ErrorHandler
 
-// ErrorNode represents a node in an error tree,
-// containing error information, error handler, and child nodes.
-type ErrorNode struct {
-	Error    error
-	Handler  ErrorHandler
-	Parent   *ErrorNode
-	Children []*ErrorNode
-}
-
-var errorTree = make([]*ErrorNode, 0)
-
-var globalMiddlewares ErrorMiddleware
-
-var fallbackHandler ErrorHandler = func(err error, ctx *gin.Context) {
+var defaultFallbackHandler ErrorHandler = func(err error, ctx *gin.Context) {
 	ctx.JSON(http.StatusInternalServerError, gin.H{
 		"error": err.Error(),
 	})
 }
 
-// Use associates error handlers with errors.
+// defaultRegistry backs the package-level Use/UseGlobal/Fallback/
+// ErrbinMiddleware functions, kept for backward compatibility with callers
+// that don't need per-subtree scoping.
+var defaultRegistry = NewRegistry()
+
+// Default returns the package-level Registry backing the top-level
+// Use, UseGlobal, Fallback, and ErrbinMiddleware functions.
+func Default() *Registry {
+	return defaultRegistry
+}
+
+// Use associates error handlers with errors on the default Registry.
 //
-// NOTE: This function is NOT concurrent-safe and must be called
-// during application initialization only.
+// NOTE: This function is NOT safe for concurrent calls and must be called
+// during application initialization only; the underlying Registry.Register
+// mutates its error tree without synchronization. This restriction is
+// per-Registry, not global: the default Registry no longer shares state
+// with Registries created for other subtrees (e.g. one per RouterGroup),
+// so setting those up independently is fine. Create your own Registry if
+// you need a separate error map, but still call its Register during that
+// Registry's own initialization only.
 func Use(handler ErrorHandler, errs ...error) error {
-	if handler == nil {
-		return fmt.Errorf("handler cannot be nil")
-	}
-	for _, newErr := range errs {
-		if newErr == nil {
-			return fmt.Errorf("cannot register nil error")
-		}
-		parent, itself := findPosition(newErr)
-		if itself != nil { // if node already exists
-			return fmt.Errorf("duplicate registration: %v", newErr)
-		} else if parent != nil { // if node is a child of another node
-			parent.Children = append(parent.Children, &ErrorNode{
-				Error:   newErr,
-				Handler: handler,
-				Parent:  parent,
-			})
-			continue
-		}
-		// if node is a father of another one
-		if chidx, children := findChildren(newErr); len(children) > 0 {
-			newNode := &ErrorNode{
-				Error:    newErr,
-				Handler:  handler,
-				Children: children,
-			}
-			removeRoots(chidx)
-			errorTree = append(errorTree, newNode)
-			continue
-		}
-		// otherwise as a new node
-		errorTree = append(errorTree, &ErrorNode{
-			Error:   newErr,
-			Handler: handler,
-		})
-	}
-	return nil
+	return defaultRegistry.Register(handler, errs...)
 }
 
-// UseGlobal registers global middlewares, which will be executed
-// before the local middlewares and local handlers
+// UseGlobal registers global middlewares on the default Registry, which
+// will be executed before the local middlewares and local handlers.
 func UseGlobal(middlewares ...ErrorMiddleware) {
-	globalMiddlewares = MiddlewareChain(middlewares...)
+	defaultRegistry.UseGlobal(middlewares...)
 }
 
 // UseWithMiddleware is a shortcut for Use()
@@ -102,8 +69,9 @@ func MiddlewareChain(middlewares ...ErrorMiddleware) ErrorMiddleware {
 	}
 }
 
-// Chain wraps handlers into a single ErrorHandler
-func Chain(handlers ...ErrorHandler) ErrorHandler {
+// ChainHandlers wraps handlers into a single ErrorHandler that runs each of
+// them in sequence. See Chain for composing ErrorMiddleware instead.
+func ChainHandlers(handlers ...ErrorHandler) ErrorHandler {
 	return func(err error, ctx *gin.Context) {
 		for _, handler := range handlers {
 			handler(err, ctx)
@@ -111,78 +79,40 @@ func Chain(handlers ...ErrorHandler) ErrorHandler {
 	}
 }
 
-// ErrbinMiddleware return a gin.HandleFunc as a middleware
-// and handle the last *gin.Error in *gin.Context.
-// If no such handler exists, then the fallbackHandler will be execute.
-func ErrbinMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		c.Next()
-		if len(c.Errors) == 0 {
-			return
-		}
-
-		err := c.Errors.Last().Err
-
-		h, found := findHandler(err)
-		if !found {
-			h = fallbackHandler
-		}
-		globalMiddlewares(h)(err, c)
-	}
+// UseChain is a first-class alternative to UseWithMiddleware that applies a
+// reusable Chain instead of a single ErrorMiddleware.
+func UseChain(chain Chain, handler ErrorHandler, errs ...error) error {
+	return defaultRegistry.UseChain(chain, handler, errs...)
 }
 
-// Fallback allows to set a customize default/fallback ErrorHandler
-func Fallback(fn ErrorHandler) {
-	fallbackHandler = fn
+// RegisterTag associates handler with tag on the default Registry. See
+// Registry.RegisterTag.
+func RegisterTag(tag Tag, handler ErrorHandler) {
+	defaultRegistry.RegisterTag(tag, handler)
 }
 
-func findPosition(target error) (*ErrorNode, *ErrorNode) {
-	var trave func(nodes []*ErrorNode) (*ErrorNode, *ErrorNode)
-	trave = func(nodes []*ErrorNode) (*ErrorNode, *ErrorNode) {
-		for _, node := range nodes {
-			if errors.Is(target, node.Error) {
-				if errors.Is(node.Error, target) {
-					return node.Parent, node
-				} else if parent, child := trave(node.Children); child != nil {
-					return parent, child
-				} else if parent == nil {
-					// if errors.Is(target, node.error) is true, target mustbe
-					// node.error itself, or its sons
-					return node, nil
-				} else {
-					return parent, nil
-				}
-			}
-		}
-		return nil, nil
-	}
-	return trave(errorTree)
+// UseTagged classifies errs under tag on the default Registry. See
+// Registry.UseTagged.
+func UseTagged(tag Tag, errs ...error) error {
+	return defaultRegistry.UseTagged(tag, errs...)
 }
 
-func findChildren(newErr error) (chidx []int, children []*ErrorNode) {
-	for i := len(errorTree) - 1; i >= 0; i-- {
-		root := errorTree[i]
-		if errors.Is(root.Error, newErr) {
-			chidx = append(chidx, i)
-			children = append(children, root)
-		}
-	}
-	return
+// SetTagger registers a Tagger on the default Registry. See
+// Registry.SetTagger.
+func SetTagger(tagger Tagger) {
+	defaultRegistry.SetTagger(tagger)
 }
 
-func removeRoots(nodes []int) {
-	for _, idx := range nodes {
-		errorTree = append(errorTree[:idx], errorTree[idx+1:]...)
-	}
+// ErrbinMiddleware returns a gin.HandlerFunc as a middleware, backed by the
+// default Registry, and handles the last *gin.Error in *gin.Context.
+// If neither the error tree nor a tag handler matches, the default
+// Registry's fallback handler is executed (see Registry.Middleware).
+func ErrbinMiddleware() gin.HandlerFunc {
+	return defaultRegistry.Middleware()
 }
 
-func findHandler(err error) (ErrorHandler, bool) {
-	parent, itself := findPosition(err)
-	if itself != nil {
-		return itself.Handler, true
-	} else if parent != nil {
-		return parent.Handler, true
-	} else {
-		return nil, false
-	}
+// Fallback allows to set a customize default/fallback ErrorHandler on the
+// default Registry.
+func Fallback(fn ErrorHandler) {
+	defaultRegistry.Fallback(fn)
 }
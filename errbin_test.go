@@ -27,7 +27,7 @@ func TestErrbin(t *testing.T) {
 			c.JSON(400, gin.H{"msg": "base"})
 		}, ErrBase)
 		assert.NoError(t, err)
-		assert.Equal(t, 1, len(errorTree))
+		assert.Equal(t, 1, len(defaultRegistry.tree))
 	})
 
 	t.Run("Register specific error", func(t *testing.T) {
@@ -35,7 +35,7 @@ func TestErrbin(t *testing.T) {
 			c.JSON(400, gin.H{"msg": "specific"})
 		}, ErrSpecific)
 		assert.NoError(t, err)
-		assert.Equal(t, 1, len(errorTree))
+		assert.Equal(t, 1, len(defaultRegistry.tree))
 	})
 
 	t.Run("Register leaf error", func(t *testing.T) {
@@ -92,8 +92,8 @@ func TestErrbin(t *testing.T) {
 	})
 
 	t.Run("Test tree struct", func(t *testing.T) {
-		assert.Equal(t, 1, len(errorTree))
-		root := errorTree[0]
+		assert.Equal(t, 1, len(defaultRegistry.tree))
+		root := defaultRegistry.tree[0]
 		assert.Equal(t, ErrBase, root.Error)
 		assert.Equal(t, 1, len(root.Children))
 		assert.Equal(t, ErrSpecific, root.Children[0].Error)
@@ -248,7 +248,7 @@ func TestErrbin(t *testing.T) {
 			callLog = append(callLog, "handler-3")
 		}
 
-		chainedHandler := Chain(h1, h2, h3)
+		chainedHandler := ChainHandlers(h1, h2, h3)
 
 		Use(chainedHandler, ErrChained)
 
@@ -283,7 +283,7 @@ func TestErrbin(t *testing.T) {
 			c.JSON(200, gin.H{"status": "all handlers executed"})
 		}
 
-		chainedHandler := Chain(h1, h2, h3)
+		chainedHandler := ChainHandlers(h1, h2, h3)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
@@ -423,12 +423,200 @@ func TestErrbin(t *testing.T) {
 	})
 }
 
+func TestRegistryAttach(t *testing.T) {
+	t.Run("child Registry handles its own registered errors", func(t *testing.T) {
+		errChild := errors.New("child error")
+
+		parent := NewRegistry()
+		r := gin.New()
+		group := r.Group("/api")
+		child := parent.Attach(group)
+		child.Register(func(err error, c *gin.Context) {
+			c.JSON(400, gin.H{"scope": "child"})
+		}, errChild)
+
+		group.GET("/test-attach-child", func(c *gin.Context) {
+			c.Error(errChild)
+		})
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/api/test-attach-child", nil))
+
+		assert.Equal(t, 400, w.Code)
+		assert.Contains(t, w.Body.String(), "child")
+	})
+
+	t.Run("unmatched error falls through to parent Registry's tree", func(t *testing.T) {
+		errShared := errors.New("shared error")
+
+		parent := NewRegistry()
+		parent.Register(func(err error, c *gin.Context) {
+			c.JSON(409, gin.H{"scope": "parent"})
+		}, errShared)
+
+		r := gin.New()
+		group := r.Group("/api")
+		parent.Attach(group)
+
+		group.GET("/test-attach-fallthrough", func(c *gin.Context) {
+			c.Error(errShared)
+		})
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/api/test-attach-fallthrough", nil))
+
+		assert.Equal(t, 409, w.Code)
+		assert.Contains(t, w.Body.String(), "parent")
+	})
+
+	t.Run("unmatched error falls through to parent Registry's fallback", func(t *testing.T) {
+		parent := NewRegistry()
+		parent.Fallback(func(err error, c *gin.Context) {
+			c.JSON(503, gin.H{"scope": "parent-fallback"})
+		})
+
+		r := gin.New()
+		group := r.Group("/api")
+		parent.Attach(group)
+
+		group.GET("/test-attach-fallback", func(c *gin.Context) {
+			c.Error(errors.New("unregistered error"))
+		})
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/api/test-attach-fallback", nil))
+
+		assert.Equal(t, 503, w.Code)
+		assert.Contains(t, w.Body.String(), "parent-fallback")
+	})
+
+	t.Run("different RouterGroups keep independent error maps", func(t *testing.T) {
+		errV1Only := errors.New("v1-only error")
+
+		root := NewRegistry()
+		r := gin.New()
+
+		v1Group := r.Group("/v1")
+		v1 := root.Attach(v1Group)
+		v1.Register(func(err error, c *gin.Context) {
+			c.JSON(400, gin.H{"scope": "v1"})
+		}, errV1Only)
+
+		v2Group := r.Group("/v2")
+		v2 := root.Attach(v2Group)
+
+		v1Group.GET("/test-scoped", func(c *gin.Context) {
+			c.Error(errV1Only)
+		})
+		v2Group.GET("/test-scoped", func(c *gin.Context) {
+			c.Error(errV1Only)
+		})
+
+		w1 := httptest.NewRecorder()
+		r.ServeHTTP(w1, httptest.NewRequest("GET", "/v1/test-scoped", nil))
+		assert.Equal(t, 400, w1.Code)
+
+		w2 := httptest.NewRecorder()
+		r.ServeHTTP(w2, httptest.NewRequest("GET", "/v2/test-scoped", nil))
+		assert.Equal(t, 500, w2.Code)
+		assert.Empty(t, v2.tree)
+	})
+}
+
+func TestChain(t *testing.T) {
+	t.Run("Append does not mutate receiver", func(t *testing.T) {
+		callOrder := []string{}
+		mark := func(name string) ErrorMiddleware {
+			return func(next ErrorHandler) ErrorHandler {
+				return func(err error, c *gin.Context) {
+					callOrder = append(callOrder, name)
+					next(err, c)
+				}
+			}
+		}
+
+		base := NewChain(mark("base"))
+		extended := base.Append(mark("extra"))
+
+		handler := func(err error, c *gin.Context) {
+			callOrder = append(callOrder, "handler")
+		}
+
+		base.Then(handler)(nil, nil)
+		assert.Equal(t, []string{"base", "handler"}, callOrder)
+
+		callOrder = nil
+		extended.Then(handler)(nil, nil)
+		assert.Equal(t, []string{"base", "extra", "handler"}, callOrder)
+	})
+
+	t.Run("Extend concatenates two chains", func(t *testing.T) {
+		callOrder := []string{}
+		mark := func(name string) ErrorMiddleware {
+			return func(next ErrorHandler) ErrorHandler {
+				return func(err error, c *gin.Context) {
+					callOrder = append(callOrder, name)
+					next(err, c)
+				}
+			}
+		}
+
+		a := NewChain(mark("a1"), mark("a2"))
+		b := NewChain(mark("b1"))
+		combined := a.Extend(b)
+
+		combined.ThenFunc(func(err error, c *gin.Context) {
+			callOrder = append(callOrder, "handler")
+		})(nil, nil)
+
+		assert.Equal(t, []string{"a1", "a2", "b1", "handler"}, callOrder)
+	})
+
+	t.Run("Test UseChain", func(t *testing.T) {
+		resetGlobalState()
+
+		ErrChain := errors.New("chain error")
+		callLog := []string{}
+
+		chain := NewChain(
+			func(next ErrorHandler) ErrorHandler {
+				return func(err error, c *gin.Context) {
+					callLog = append(callLog, "mw")
+					next(err, c)
+				}
+			},
+		)
+
+		err := UseChain(chain, func(err error, c *gin.Context) {
+			callLog = append(callLog, "handler")
+			c.JSON(409, gin.H{"error": "conflict"})
+		}, ErrChain)
+		assert.NoError(t, err)
+
+		r := gin.New()
+		r.Use(ErrbinMiddleware())
+		r.GET("/test-usechain", func(c *gin.Context) {
+			c.Error(ErrChain)
+		})
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/test-usechain", nil))
+
+		assert.Equal(t, []string{"mw", "handler"}, callLog)
+		assert.Equal(t, 409, w.Code)
+		assert.Contains(t, w.Body.String(), "conflict")
+	})
+}
+
 func resetGlobalState() {
-	errorTree = nil
-	globalMiddlewares = nil
-	fallbackHandler = func(err error, ctx *gin.Context) {
+	defaultRegistry.tree = nil
+	defaultRegistry.middlewares = nil
+	defaultRegistry.fallback = func(err error, ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, gin.H{
 			"error": err.Error(),
 		})
 	}
+	defaultRegistry.tagHandlers = nil
+	defaultRegistry.taggedErrors = nil
+	defaultRegistry.tagger = nil
 }
@@ -1,18 +1,16 @@
 package errbin
 
-// errorNode represents a node in an error tree,
+// ErrorNode represents a node in an error tree,
 // containing error information, error handler, and child nodes.
-type errorNode struct {
+type ErrorNode struct {
 	Error    error
 	Handler  ErrorHandler
-	Parent   *errorNode
-	Children []*errorNode
+	Parent   *ErrorNode
+	Children []*ErrorNode
 }
 
-var errorTree = make([]*errorNode, 0)
-
-func removeRoots(nodes []int) {
+func (r *Registry) removeRoots(nodes []int) {
 	for _, idx := range nodes {
-		errorTree = append(errorTree[:idx], errorTree[idx+1:]...)
+		r.tree = append(r.tree[:idx], r.tree[idx+1:]...)
 	}
 }
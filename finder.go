@@ -2,9 +2,9 @@ package errbin
 
 import "errors"
 
-func findPosition(target error) (*errorNode, *errorNode) {
-	var trave func(nodes []*errorNode) (*errorNode, *errorNode)
-	trave = func(nodes []*errorNode) (*errorNode, *errorNode) {
+func (r *Registry) findPosition(target error) (*ErrorNode, *ErrorNode) {
+	var trave func(nodes []*ErrorNode) (*ErrorNode, *ErrorNode)
+	trave = func(nodes []*ErrorNode) (*ErrorNode, *ErrorNode) {
 		for _, node := range nodes {
 			if errors.Is(target, node.Error) {
 				if errors.Is(node.Error, target) {
@@ -22,12 +22,12 @@ func findPosition(target error) (*errorNode, *errorNode) {
 		}
 		return nil, nil
 	}
-	return trave(errorTree)
+	return trave(r.tree)
 }
 
-func findChildren(newErr error) (chidx []int, children []*errorNode) {
-	for i := len(errorTree) - 1; i >= 0; i-- {
-		root := errorTree[i]
+func (r *Registry) findChildren(newErr error) (chidx []int, children []*ErrorNode) {
+	for i := len(r.tree) - 1; i >= 0; i-- {
+		root := r.tree[i]
 		if errors.Is(root.Error, newErr) {
 			chidx = append(chidx, i)
 			children = append(children, root)
@@ -36,13 +36,16 @@ func findChildren(newErr error) (chidx []int, children []*errorNode) {
 	return
 }
 
-func findHandler(err error) (ErrorHandler, bool) {
-	parent, itself := findPosition(err)
+// findHandler looks up a handler for err in r's own tree, falling through
+// to r's parent Registry (set via Attach) when r has no match.
+func (r *Registry) findHandler(err error) (ErrorHandler, bool) {
+	parent, itself := r.findPosition(err)
 	if itself != nil {
 		return itself.Handler, true
 	} else if parent != nil {
 		return parent.Handler, true
-	} else {
-		return nil, false
+	} else if r.parent != nil {
+		return r.parent.findHandler(err)
 	}
+	return nil, false
 }
@@ -0,0 +1,174 @@
+package errbin
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MIME types for RFC 7807 Problem Details responses.
+const (
+	MIMEProblemJSON = "application/problem+json"
+	MIMEProblemXML  = "application/problem+xml"
+)
+
+// Problem is an RFC 7807 (https://www.rfc-editor.org/rfc/rfc7807) Problem
+// Details object. Extensions holds any additional members a caller wants to
+// surface; on JSON responses they are merged into the top-level object
+// alongside Type/Title/Status/Detail/Instance, per the RFC.
+type Problem struct {
+	XMLName    xml.Name       `json:"-" xml:"urn:ietf:rfc:7807 problem"`
+	Type       string         `json:"type,omitempty" xml:"type,omitempty"`
+	Title      string         `json:"title,omitempty" xml:"title,omitempty"`
+	Status     int            `json:"status,omitempty" xml:"status,omitempty"`
+	Detail     string         `json:"detail,omitempty" xml:"detail,omitempty"`
+	Instance   string         `json:"instance,omitempty" xml:"instance,omitempty"`
+	Extensions map[string]any `json:"-" xml:"-"`
+}
+
+// MarshalJSON flattens Extensions into the top-level object, as RFC 7807
+// requires extension members to sit alongside the standard ones rather
+// than nested under a key.
+func (p Problem) MarshalJSON() ([]byte, error) {
+	out := make(map[string]any, len(p.Extensions)+5)
+	for k, v := range p.Extensions {
+		out[k] = v
+	}
+	if p.Type != "" {
+		out["type"] = p.Type
+	}
+	if p.Title != "" {
+		out["title"] = p.Title
+	}
+	if p.Status != 0 {
+		out["status"] = p.Status
+	}
+	if p.Detail != "" {
+		out["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		out["instance"] = p.Instance
+	}
+	return json.Marshal(out)
+}
+
+// ProblemDetails builds an ErrorHandler that renders mapper's Problem as an
+// RFC 7807 response, honoring the request's Accept header: JSON accepters
+// get application/problem+json, XML accepters get application/problem+xml,
+// and anything else falls back to text/plain. The response status is taken
+// from Problem.Status.
+func ProblemDetails(mapper func(error) Problem) ErrorHandler {
+	return func(err error, c *gin.Context) {
+		p := mapper(err)
+		status := p.Status
+		if status == 0 {
+			status = http.StatusInternalServerError
+		}
+
+		// Offer both the problem-specific and plain media types so a client
+		// that sends a generic "Accept: application/json" (rather than the
+		// more precise "application/problem+json") still negotiates JSON.
+		switch c.NegotiateFormat(MIMEProblemJSON, gin.MIMEJSON, MIMEProblemXML, gin.MIMEXML, gin.MIMEPlain) {
+		case MIMEProblemXML, gin.MIMEXML:
+			body, marshalErr := xml.Marshal(p)
+			if marshalErr != nil {
+				c.Status(http.StatusInternalServerError)
+				return
+			}
+			c.Data(status, MIMEProblemXML, body)
+		case MIMEProblemJSON, gin.MIMEJSON:
+			body, marshalErr := json.Marshal(p)
+			if marshalErr != nil {
+				c.Status(http.StatusInternalServerError)
+				return
+			}
+			c.Data(status, MIMEProblemJSON, body)
+		default:
+			c.Data(status, gin.MIMEPlain, []byte(problemText(p)))
+		}
+	}
+}
+
+func problemText(p Problem) string {
+	if p.Detail != "" {
+		return p.Title + ": " + p.Detail
+	}
+	return p.Title
+}
+
+// DefaultProblemMapper returns a Problem mapper for the default Registry.
+// See Registry.DefaultProblemMapper.
+func DefaultProblemMapper() func(error) Problem {
+	return defaultRegistry.DefaultProblemMapper()
+}
+
+// DefaultProblemMapper builds a Problem mapper that walks r's registered
+// error tree to derive sensible defaults: Type is a stable
+// "about:blank#<error-name>" URI built from the matched node's error
+// identity, and Title comes from the top-most registered ancestor of that
+// node. Errors with no registered match fall back to a generic
+// Internal Server Error Problem. All Problems default to a 500 status,
+// since the tree does not carry a status code of its own; wrap the
+// returned mapper if a different default is needed.
+func (r *Registry) DefaultProblemMapper() func(error) Problem {
+	return func(err error) Problem {
+		node := r.matchedNode(err)
+		if node == nil {
+			return Problem{
+				Type:   "about:blank",
+				Title:  "Internal Server Error",
+				Status: http.StatusInternalServerError,
+				Detail: err.Error(),
+			}
+		}
+
+		root := node
+		for root.Parent != nil {
+			root = root.Parent
+		}
+
+		return Problem{
+			Type:   "about:blank#" + errorName(node.Error),
+			Title:  errorName(root.Error),
+			Status: http.StatusInternalServerError,
+			Detail: err.Error(),
+		}
+	}
+}
+
+// matchedNode returns the ErrorNode that findHandler would dispatch to for
+// err, falling through to r's parent Registry, or nil if nothing matches.
+func (r *Registry) matchedNode(err error) *ErrorNode {
+	parent, itself := r.findPosition(err)
+	if itself != nil {
+		return itself
+	}
+	if parent != nil {
+		return parent
+	}
+	if r.parent != nil {
+		return r.parent.matchedNode(err)
+	}
+	return nil
+}
+
+// errorName derives a stable, URL-safe slug from an error's message, for
+// use in Problem.Type/Title defaults.
+func errorName(err error) string {
+	var b strings.Builder
+	prevDash := true // avoid a leading dash
+	for _, r := range strings.ToLower(err.Error()) {
+		switch {
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9':
+			b.WriteRune(r)
+			prevDash = false
+		case !prevDash:
+			b.WriteByte('-')
+			prevDash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
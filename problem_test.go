@@ -0,0 +1,103 @@
+package errbin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	errNotFoundForTest     = errors.New("not found error for test")
+	errBaseForTest         = errors.New("base error for test")
+	errSpecificForTest     = fmt.Errorf("specific error for test: %w", errBaseForTest)
+	errUnregisteredForTest = errors.New("unregistered error for test")
+)
+
+func TestProblemDetails(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mapper := func(err error) Problem {
+		return Problem{
+			Type:       "about:blank#not-found",
+			Title:      "Not Found",
+			Status:     http.StatusNotFound,
+			Detail:     err.Error(),
+			Extensions: map[string]any{"code": "E404"},
+		}
+	}
+
+	newRouter := func() *gin.Engine {
+		resetGlobalState()
+		Use(ProblemDetails(mapper), errNotFoundForTest)
+		r := gin.New()
+		r.Use(ErrbinMiddleware())
+		r.GET("/test-problem", func(c *gin.Context) {
+			c.Error(errNotFoundForTest)
+		})
+		return r
+	}
+
+	t.Run("JSON accepters get application/problem+json", func(t *testing.T) {
+		r := newRouter()
+		req := httptest.NewRequest("GET", "/test-problem", nil)
+		req.Header.Set("Accept", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, MIMEProblemJSON, w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Body.String(), `"title":"Not Found"`)
+		assert.Contains(t, w.Body.String(), `"code":"E404"`)
+	})
+
+	t.Run("XML accepters get application/problem+xml", func(t *testing.T) {
+		r := newRouter()
+		req := httptest.NewRequest("GET", "/test-problem", nil)
+		req.Header.Set("Accept", "application/xml")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, MIMEProblemXML, w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Body.String(), "<title>Not Found</title>")
+	})
+
+	t.Run("unrecognized accept falls back to text/plain", func(t *testing.T) {
+		r := newRouter()
+		req := httptest.NewRequest("GET", "/test-problem", nil)
+		req.Header.Set("Accept", "text/html")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "text/plain")
+		assert.Equal(t, "Not Found: not found error for test", w.Body.String())
+	})
+}
+
+func TestDefaultProblemMapper(t *testing.T) {
+	resetGlobalState()
+
+	Use(func(err error, c *gin.Context) {}, errBaseForTest)
+	Use(func(err error, c *gin.Context) {}, errSpecificForTest)
+
+	mapper := DefaultProblemMapper()
+
+	t.Run("matched node derives Type, root ancestor derives Title", func(t *testing.T) {
+		p := mapper(errSpecificForTest)
+		assert.Equal(t, "about:blank#specific-error-for-test-base-error-for-test", p.Type)
+		assert.Equal(t, "base-error-for-test", p.Title)
+		assert.Equal(t, http.StatusInternalServerError, p.Status)
+	})
+
+	t.Run("unregistered error falls back to a generic Problem", func(t *testing.T) {
+		p := mapper(errUnregisteredForTest)
+		assert.Equal(t, "about:blank", p.Type)
+		assert.Equal(t, "Internal Server Error", p.Title)
+	})
+}
@@ -0,0 +1,160 @@
+package errbin
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"runtime"
+	"syscall"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrPanic is the sentinel error under which recovered panics are
+// registered. Use it like any other registered error:
+//
+//	Use(handler, errbin.ErrPanic)
+var ErrPanic = errors.New("errbin: panic recovered")
+
+// StackTraceKey is the *gin.Context key under which RecoveryMiddleware
+// stashes the captured stack trace ([]byte), so handlers registered
+// against ErrPanic can render it.
+const StackTraceKey = "errbin_stack_trace"
+
+const defaultStackDepth = 32
+
+// PanicError wraps a recovered panic value into an error. It satisfies
+// errors.Is against ErrPanic, and unwraps to the recovered value itself
+// when that value is an error, so handlers can also match on the concrete
+// panic value (e.g. Use(handler, sql.ErrNoRows)).
+type PanicError struct {
+	// Value is the value passed to panic().
+	Value any
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("errbin: panic recovered: %v", e.Value)
+}
+
+// Is reports whether target is ErrPanic, so every PanicError matches the
+// ErrPanic sentinel regardless of the underlying panic value.
+func (e *PanicError) Is(target error) bool {
+	return target == ErrPanic
+}
+
+// Unwrap returns the recovered value when it was itself an error, so
+// errors.Is/As can keep matching against it.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// RecoveryOption configures RecoveryMiddleware.
+type RecoveryOption func(*recoveryConfig)
+
+type recoveryConfig struct {
+	stackDepth         int
+	onPanic            func(any, []byte)
+	suppressBrokenPipe bool
+}
+
+// WithStackDepth bounds the number of stack frames captured for a
+// recovered panic. depth <= 0 falls back to a sane default.
+func WithStackDepth(depth int) RecoveryOption {
+	return func(c *recoveryConfig) {
+		c.stackDepth = depth
+	}
+}
+
+// WithOnPanic registers a hook invoked with the recovered value and its
+// captured stack trace, e.g. for logging or metrics. It runs before the
+// error is dispatched through the error tree.
+func WithOnPanic(fn func(value any, stack []byte)) RecoveryOption {
+	return func(c *recoveryConfig) {
+		c.onPanic = fn
+	}
+}
+
+// WithSuppressBrokenPipe controls what happens when the recovered value is
+// a broken-pipe/connection-reset net.OpError: by default (false) these are
+// re-panicked, since the connection is already gone and there's no client
+// left to respond to; set true to instead dispatch them through the error
+// tree like any other panic.
+func WithSuppressBrokenPipe(suppress bool) RecoveryOption {
+	return func(c *recoveryConfig) {
+		c.suppressBrokenPipe = suppress
+	}
+}
+
+// RecoveryMiddleware returns a gin.HandlerFunc that recovers panics,
+// wraps the recovered value into a *PanicError, attaches it with c.Error,
+// and lets the request continue so the normal ErrbinMiddleware pipeline
+// dispatches it like any other error.
+//
+// Register it after ErrbinMiddleware/Registry.Middleware so the panic's
+// *gin.Error is already attached by the time that middleware inspects
+// c.Errors:
+//
+//	r.Use(errbin.ErrbinMiddleware())
+//	r.Use(errbin.RecoveryMiddleware())
+func RecoveryMiddleware(opts ...RecoveryOption) gin.HandlerFunc {
+	cfg := recoveryConfig{stackDepth: defaultStackDepth}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return func(c *gin.Context) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+
+			if !cfg.suppressBrokenPipe && isBrokenPipe(rec) {
+				panic(rec)
+			}
+
+			stack := captureStack(cfg.stackDepth)
+			c.Set(StackTraceKey, stack)
+
+			if cfg.onPanic != nil {
+				cfg.onPanic(rec, stack)
+			}
+
+			c.Error(&PanicError{Value: rec}) //nolint: errcheck
+		}()
+		c.Next()
+	}
+}
+
+func isBrokenPipe(rec any) bool {
+	err, ok := rec.(error)
+	if !ok {
+		return false
+	}
+	var opErr *net.OpError
+	if !errors.As(err, &opErr) {
+		return false
+	}
+	return errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ECONNRESET)
+}
+
+func captureStack(depth int) []byte {
+	if depth <= 0 {
+		depth = defaultStackDepth
+	}
+	pcs := make([]uintptr, depth)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var buf bytes.Buffer
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&buf, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return buf.Bytes()
+}
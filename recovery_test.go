@@ -0,0 +1,124 @@
+package errbin
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecoveryMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("dispatches recovered panic through the error tree", func(t *testing.T) {
+		resetGlobalState()
+
+		var gotStack []byte
+		Use(func(err error, c *gin.Context) {
+			gotStack, _ = c.Value(StackTraceKey).([]byte)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}, ErrPanic)
+
+		r := gin.New()
+		r.Use(ErrbinMiddleware())
+		r.Use(RecoveryMiddleware())
+		r.GET("/test-panic", func(c *gin.Context) {
+			panic("boom")
+		})
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/test-panic", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Body.String(), "boom")
+		assert.NotEmpty(t, gotStack)
+	})
+
+	t.Run("PanicError unwraps to a panicked error value", func(t *testing.T) {
+		resetGlobalState()
+
+		sentinelErr := errors.New("sentinel error")
+		Use(func(err error, c *gin.Context) {
+			c.JSON(http.StatusTeapot, gin.H{"matched": "sentinel"})
+		}, sentinelErr)
+
+		r := gin.New()
+		r.Use(ErrbinMiddleware())
+		r.Use(RecoveryMiddleware())
+		r.GET("/test-panic-err", func(c *gin.Context) {
+			panic(sentinelErr)
+		})
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/test-panic-err", nil))
+
+		assert.Equal(t, http.StatusTeapot, w.Code)
+		assert.Contains(t, w.Body.String(), "sentinel")
+	})
+
+	t.Run("OnPanic hook runs before dispatch", func(t *testing.T) {
+		resetGlobalState()
+
+		Use(func(err error, c *gin.Context) {
+			c.Status(http.StatusInternalServerError)
+		}, ErrPanic)
+
+		var hookValue any
+		var hookStack []byte
+
+		r := gin.New()
+		r.Use(ErrbinMiddleware())
+		r.Use(RecoveryMiddleware(WithOnPanic(func(value any, stack []byte) {
+			hookValue = value
+			hookStack = stack
+		})))
+		r.GET("/test-panic-hook", func(c *gin.Context) {
+			panic("hooked")
+		})
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/test-panic-hook", nil))
+
+		assert.Equal(t, "hooked", hookValue)
+		assert.NotEmpty(t, hookStack)
+	})
+
+	t.Run("broken pipe re-panics by default", func(t *testing.T) {
+		resetGlobalState()
+
+		r := gin.New()
+		r.Use(RecoveryMiddleware())
+		r.GET("/test-broken-pipe", func(c *gin.Context) {
+			panic(&net.OpError{Op: "write", Err: syscall.EPIPE})
+		})
+
+		assert.Panics(t, func() {
+			r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/test-broken-pipe", nil))
+		})
+	})
+
+	t.Run("WithSuppressBrokenPipe dispatches instead of re-panicking", func(t *testing.T) {
+		resetGlobalState()
+
+		Use(func(err error, c *gin.Context) {
+			c.Status(http.StatusServiceUnavailable)
+		}, ErrPanic)
+
+		r := gin.New()
+		r.Use(ErrbinMiddleware())
+		r.Use(RecoveryMiddleware(WithSuppressBrokenPipe(true)))
+		r.GET("/test-broken-pipe-suppressed", func(c *gin.Context) {
+			panic(&net.OpError{Op: "write", Err: syscall.ECONNRESET})
+		})
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/test-broken-pipe-suppressed", nil))
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+}
@@ -0,0 +1,198 @@
+package errbin
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Registry owns an error tree, a middleware chain, and a fallback handler.
+// Unlike the package-level functions it replaces, a Registry carries no
+// shared state with other Registries, so independent subtrees of an
+// application (e.g. different RouterGroups) can maintain their own error
+// maps and fallbacks.
+//
+// A Registry's zero value is an empty Registry that uses the built-in JSON
+// fallback handler, the same as what NewRegistry returns.
+type Registry struct {
+	tree         []*ErrorNode
+	middlewares  ErrorMiddleware
+	fallback     ErrorHandler
+	parent       *Registry
+	tagHandlers  []tagHandler
+	taggedErrors []taggedError
+	tagger       Tagger
+}
+
+// NewRegistry creates a standalone Registry with the built-in JSON fallback
+// handler and no parent.
+func NewRegistry() *Registry {
+	return &Registry{fallback: defaultFallbackHandler}
+}
+
+// Attach creates a Registry scoped to rg and installs its Middleware on the
+// group. Routes registered under rg that raise an error unhandled by the
+// child Registry fall through to r, so subtrees like /api/v1 and /api/v2
+// can keep independent error maps while still sharing a common parent
+// fallback or handlers.
+func (r *Registry) Attach(rg *gin.RouterGroup) *Registry {
+	child := &Registry{parent: r}
+	rg.Use(child.Middleware())
+	return child
+}
+
+// Register associates error handlers with errors.
+//
+// NOTE: This method mutates r's error tree without synchronization, so
+// concurrent calls to Register on the same Registry are NOT safe; call it
+// during that Registry's own initialization only. This is unchanged from
+// the old package-level Use: what Registry adds is that each Registry
+// owns its own tree, so independent Registries (e.g. one per RouterGroup)
+// no longer share mutable state and can be set up without racing each
+// other.
+func (r *Registry) Register(handler ErrorHandler, errs ...error) error {
+	if handler == nil {
+		return fmt.Errorf("handler cannot be nil")
+	}
+	for _, newErr := range errs {
+		if newErr == nil {
+			return fmt.Errorf("cannot register nil error")
+		}
+		parent, itself := r.findPosition(newErr)
+		if itself != nil { // if node already exists
+			return fmt.Errorf("duplicate registration: %v", newErr)
+		} else if parent != nil { // if node is a child of another node
+			parent.Children = append(parent.Children, &ErrorNode{
+				Error:   newErr,
+				Handler: handler,
+				Parent:  parent,
+			})
+			continue
+		}
+		// if node is a father of another one
+		if chidx, children := r.findChildren(newErr); len(children) > 0 {
+			newNode := &ErrorNode{
+				Error:    newErr,
+				Handler:  handler,
+				Children: children,
+			}
+			r.removeRoots(chidx)
+			r.tree = append(r.tree, newNode)
+			continue
+		}
+		// otherwise as a new node
+		r.tree = append(r.tree, &ErrorNode{
+			Error:   newErr,
+			Handler: handler,
+		})
+	}
+	return nil
+}
+
+// UseGlobal registers global middlewares, which will be executed before the
+// local middlewares and local handlers.
+func (r *Registry) UseGlobal(middlewares ...ErrorMiddleware) {
+	r.middlewares = MiddlewareChain(middlewares...)
+}
+
+// UseWithMiddleware is a shortcut for Register()
+func (r *Registry) UseWithMiddleware(middleware ErrorMiddleware, handler ErrorHandler, errs ...error) error {
+	return r.Register(func(err error, ctx *gin.Context) {
+		middleware(handler)(err, ctx)
+	}, errs...)
+}
+
+// UseChain is a first-class alternative to UseWithMiddleware that applies a
+// reusable Chain instead of a single ErrorMiddleware.
+func (r *Registry) UseChain(chain Chain, handler ErrorHandler, errs ...error) error {
+	return r.Register(chain.Then(handler), errs...)
+}
+
+// Fallback allows to set a customize default/fallback ErrorHandler. A nil
+// fn is a no-op, leaving the previously configured fallback in place.
+func (r *Registry) Fallback(fn ErrorHandler) {
+	if fn == nil {
+		return
+	}
+	r.fallback = fn
+}
+
+// RegisterTag associates handler with tag. tag may be a bitwise OR of
+// several Tags (e.g. TagClient|TagValidation), in which case handler runs
+// for an error classified under any one of them.
+func (r *Registry) RegisterTag(tag Tag, handler ErrorHandler) {
+	r.tagHandlers = append(r.tagHandlers, tagHandler{mask: tag, handler: handler})
+}
+
+// UseTagged classifies errs under tag, so that a handler registered via
+// RegisterTag for an overlapping tag dispatches them. An error that also
+// matches the error tree is still handled by its tree handler, since the
+// tree is consulted before tags.
+func (r *Registry) UseTagged(tag Tag, errs ...error) error {
+	for _, err := range errs {
+		if err == nil {
+			return fmt.Errorf("cannot register nil error")
+		}
+		r.taggedErrors = append(r.taggedErrors, taggedError{err: err, tag: tag})
+	}
+	return nil
+}
+
+// SetTagger registers a Tagger that classifies errors that weren't
+// explicitly associated with a tag via UseTagged, e.g. anything wrapping
+// context.DeadlineExceeded -> TagTimeout. It is consulted only after
+// UseTagged's explicit associations find no match.
+func (r *Registry) SetTagger(tagger Tagger) {
+	r.tagger = tagger
+}
+
+// Middleware returns a gin.HandlerFunc that handles the last *gin.Error in
+// *gin.Context. Lookup tries, in order: an exact match in r's error tree,
+// a tag match (see RegisterTag/UseTagged/SetTagger), and finally r's
+// fallback handler. Tree and tag lookups both fall through to r's parent
+// Registry (set via Attach) when r itself has no match.
+func (r *Registry) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		if len(c.Errors) == 0 {
+			return
+		}
+
+		err := c.Errors.Last().Err
+
+		h, found := r.findHandler(err)
+		if !found {
+			h, found = r.findTagHandler(err)
+		}
+		if !found {
+			h = r.resolveFallback()
+		}
+		r.resolveMiddleware()(h)(err, c)
+	}
+}
+
+// resolveFallback returns r's own fallback handler, falling through to r's
+// parent chain, and finally to the built-in JSON fallback if none was ever
+// set (e.g. a Registry constructed as part of the Registry struct literal
+// rather than via NewRegistry).
+func (r *Registry) resolveFallback() ErrorHandler {
+	if r.fallback != nil {
+		return r.fallback
+	}
+	if r.parent != nil {
+		return r.parent.resolveFallback()
+	}
+	return defaultFallbackHandler
+}
+
+// resolveMiddleware returns r's own global middleware chain, falling
+// through to r's parent chain, and finally to the identity middleware.
+func (r *Registry) resolveMiddleware() ErrorMiddleware {
+	if r.middlewares != nil {
+		return r.middlewares
+	}
+	if r.parent != nil {
+		return r.parent.resolveMiddleware()
+	}
+	return func(eh ErrorHandler) ErrorHandler { return eh }
+}
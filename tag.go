@@ -0,0 +1,77 @@
+package errbin
+
+import "errors"
+
+// Tag is a bitmask identifying a category of errors (e.g. "all timeouts",
+// "all validation errors"), for dispatch that doesn't require registering
+// every concrete error individually. Tags compose with bitwise OR:
+//
+//	const (
+//		TagClient Tag = 1 << iota
+//		TagValidation
+//		TagTimeout
+//	)
+//	registry.RegisterTag(TagClient|TagValidation, handleBadRequest)
+type Tag uint64
+
+// Tagger classifies an error into a Tag. Once registered via
+// Registry.SetTagger, it's consulted for errors with no explicit UseTagged
+// association. A zero Tag means "no classification".
+type Tagger func(error) Tag
+
+type taggedError struct {
+	err error
+	tag Tag
+}
+
+type tagHandler struct {
+	mask    Tag
+	handler ErrorHandler
+}
+
+// classify returns the Tag err was associated with via UseTagged, falling
+// back to r's Tagger, and finally to r's parent Registry.
+func (r *Registry) classify(err error) (Tag, bool) {
+	for _, te := range r.taggedErrors {
+		if errors.Is(err, te.err) {
+			return te.tag, true
+		}
+	}
+	if r.tagger != nil {
+		if tag := r.tagger(err); tag != 0 {
+			return tag, true
+		}
+	}
+	if r.parent != nil {
+		return r.parent.classify(err)
+	}
+	return 0, false
+}
+
+// findTagHandler returns the handler registered for any tag that overlaps
+// err's classification. err is classified once via r.classify (which
+// already falls through to r's parent), and the resulting tag is then
+// used to walk r.tagHandlers up the parent chain, so a tag resolved from a
+// child's UseTagged/SetTagger still matches a RegisterTag handler that
+// lives on a parent Registry.
+func (r *Registry) findTagHandler(err error) (ErrorHandler, bool) {
+	tag, ok := r.classify(err)
+	if !ok {
+		return nil, false
+	}
+	return r.findHandlerForTag(tag)
+}
+
+// findHandlerForTag returns the handler registered for any tag that
+// overlaps tag, falling through to r's parent Registry.
+func (r *Registry) findHandlerForTag(tag Tag) (ErrorHandler, bool) {
+	for _, th := range r.tagHandlers {
+		if th.mask&tag != 0 {
+			return th.handler, true
+		}
+	}
+	if r.parent != nil {
+		return r.parent.findHandlerForTag(tag)
+	}
+	return nil, false
+}
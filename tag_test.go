@@ -0,0 +1,178 @@
+package errbin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	tagClientForTest Tag = 1 << iota
+	tagValidationForTest
+	tagTimeoutForTest
+)
+
+func TestTagDispatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("exact tree match wins over a tag match", func(t *testing.T) {
+		resetGlobalState()
+
+		errSentinel := errors.New("sentinel error")
+		Use(func(err error, c *gin.Context) {
+			c.Status(http.StatusTeapot)
+		}, errSentinel)
+		RegisterTag(tagClientForTest, func(err error, c *gin.Context) {
+			c.Status(http.StatusBadRequest)
+		})
+		UseTagged(tagClientForTest, errSentinel)
+
+		r := gin.New()
+		r.Use(ErrbinMiddleware())
+		r.GET("/test-tag-exact", func(c *gin.Context) {
+			c.Error(errSentinel)
+		})
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/test-tag-exact", nil))
+
+		assert.Equal(t, http.StatusTeapot, w.Code)
+	})
+
+	t.Run("UseTagged dispatches an unregistered error via its tag handler", func(t *testing.T) {
+		resetGlobalState()
+
+		errSentinel := errors.New("sentinel error")
+		RegisterTag(tagClientForTest, func(err error, c *gin.Context) {
+			c.JSON(http.StatusBadRequest, gin.H{"tag": "client"})
+		})
+		UseTagged(tagClientForTest, errSentinel)
+
+		r := gin.New()
+		r.Use(ErrbinMiddleware())
+		r.GET("/test-tag-used", func(c *gin.Context) {
+			c.Error(errSentinel)
+		})
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/test-tag-used", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "client")
+	})
+
+	t.Run("RegisterTag matches a composed bitwise OR of tags", func(t *testing.T) {
+		resetGlobalState()
+
+		errSentinel := errors.New("sentinel error")
+		RegisterTag(tagClientForTest|tagValidationForTest, func(err error, c *gin.Context) {
+			c.Status(http.StatusUnprocessableEntity)
+		})
+		UseTagged(tagValidationForTest, errSentinel)
+
+		r := gin.New()
+		r.Use(ErrbinMiddleware())
+		r.GET("/test-tag-composed", func(c *gin.Context) {
+			c.Error(errSentinel)
+		})
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/test-tag-composed", nil))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("SetTagger classifies errors with no explicit UseTagged association", func(t *testing.T) {
+		resetGlobalState()
+
+		SetTagger(func(err error) Tag {
+			if errors.Is(err, context.DeadlineExceeded) {
+				return tagTimeoutForTest
+			}
+			return 0
+		})
+		RegisterTag(tagTimeoutForTest, func(err error, c *gin.Context) {
+			c.Status(http.StatusGatewayTimeout)
+		})
+
+		r := gin.New()
+		r.Use(ErrbinMiddleware())
+		r.GET("/test-tagger", func(c *gin.Context) {
+			c.Error(fmt.Errorf("waiting on upstream: %w", context.DeadlineExceeded))
+		})
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/test-tagger", nil))
+
+		assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+	})
+
+	t.Run("UseTagged takes priority over SetTagger", func(t *testing.T) {
+		resetGlobalState()
+
+		errSentinel := errors.New("sentinel error")
+		SetTagger(func(err error) Tag { return tagTimeoutForTest })
+		RegisterTag(tagTimeoutForTest, func(err error, c *gin.Context) {
+			c.Status(http.StatusGatewayTimeout)
+		})
+		RegisterTag(tagClientForTest, func(err error, c *gin.Context) {
+			c.Status(http.StatusBadRequest)
+		})
+		UseTagged(tagClientForTest, errSentinel)
+
+		r := gin.New()
+		r.Use(ErrbinMiddleware())
+		r.GET("/test-tag-priority", func(c *gin.Context) {
+			c.Error(errSentinel)
+		})
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/test-tag-priority", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("no tag match falls through to fallback", func(t *testing.T) {
+		resetGlobalState()
+
+		r := gin.New()
+		r.Use(ErrbinMiddleware())
+		r.GET("/test-tag-fallback", func(c *gin.Context) {
+			c.Error(errors.New("unclassified error"))
+		})
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/test-tag-fallback", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("child Registry tag lookup falls through to parent", func(t *testing.T) {
+		errSentinel := errors.New("sentinel error")
+
+		parent := NewRegistry()
+		parent.RegisterTag(tagClientForTest, func(err error, c *gin.Context) {
+			c.Status(http.StatusBadRequest)
+		})
+
+		r := gin.New()
+		group := r.Group("/api")
+		child := parent.Attach(group)
+		child.UseTagged(tagClientForTest, errSentinel)
+
+		group.GET("/test-tag-parent", func(c *gin.Context) {
+			c.Error(errSentinel)
+		})
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/api/test-tag-parent", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}